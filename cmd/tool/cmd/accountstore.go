@@ -0,0 +1,180 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// accountSchemaVersion is bumped whenever the on-disk record layout changes;
+// migrateAccountStore uses it to decide which migrations to run.
+const accountSchemaVersion = 1
+
+const schemaVersionKey = "schema-version"
+
+var storeFile string
+var accountStore *levelDBAccountStore
+
+// accountRecord is the durable, JSON-encoded counterpart of balance. balance
+// itself keeps unexported fields and a live Signer, neither of which
+// round-trip through encoding/json.
+type accountRecord struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"privateKey"`
+	Amount     int    `json:"amount"`
+	Shard      uint   `json:"shard"`
+	Nonce      uint64 `json:"nonce"`
+	Tx         string `json:"tx,omitempty"`
+	Packed     bool   `json:"packed"`
+}
+
+// levelDBAccountStore persists the balance list across runs so sendtx can
+// resume after a crash without re-scanning every account via
+// seele.GetBalance/getNonce.
+type levelDBAccountStore struct {
+	db *leveldb.DB
+}
+
+// openAccountStore opens (or creates) the account store living next to
+// keyFile, running any pending schema migration first.
+func openAccountStore(path string) (*levelDBAccountStore, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open account store: %s", err)
+	}
+
+	store := &levelDBAccountStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// migrate brings an existing store up to accountSchemaVersion, so the record
+// layout can evolve without discarding accumulated state.
+func (s *levelDBAccountStore) migrate() error {
+	raw, err := s.db.Get([]byte(schemaVersionKey), nil)
+	version := 0
+	if err == nil {
+		version = int(raw[0])
+	} else if err != leveldb.ErrNotFound {
+		return fmt.Errorf("read schema version: %s", err)
+	}
+
+	// No migrations exist yet; this is the hook future schema changes hang
+	// off of, e.g. "if version < 2 { ... }".
+	if version > accountSchemaVersion {
+		return fmt.Errorf("account store schema version %d is newer than supported %d", version, accountSchemaVersion)
+	}
+
+	return s.db.Put([]byte(schemaVersionKey), []byte{byte(accountSchemaVersion)}, nil)
+}
+
+// Load returns every account currently recorded in the store.
+func (s *levelDBAccountStore) Load() ([]*balance, error) {
+	result := make([]*balance, 0)
+
+	iter := s.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := string(iter.Key())
+		if key == schemaVersionKey {
+			continue
+		}
+
+		var rec accountRecord
+		if err := json.Unmarshal(iter.Value(), &rec); err != nil {
+			return nil, fmt.Errorf("decode account record %s: %s", key, err)
+		}
+
+		b, err := rec.toBalance()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, b)
+	}
+
+	return result, iter.Error()
+}
+
+// Save durably records b, including its current nonce and in-flight tx, so
+// loopCheck's tx->confirmation transitions survive a crash. Hardware-backed
+// signers (e.g. Ledger) never expose their private key, so their balance
+// keeps resuming from a fresh RPC scan rather than the store.
+func (s *levelDBAccountStore) Save(b *balance) error {
+	if _, ok := b.signer.(exportableSigner); !ok {
+		return nil
+	}
+
+	rec := newAccountRecord(b)
+
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode account record: %s", err)
+	}
+
+	return s.db.Put([]byte(b.address.ToHex()), raw, nil)
+}
+
+func (s *levelDBAccountStore) Close() error {
+	return s.db.Close()
+}
+
+func newAccountRecord(b *balance) accountRecord {
+	rec := accountRecord{
+		Address:    b.address.ToHex(),
+		PrivateKey: b.signer.(exportableSigner).PrivateKeyHex(),
+		Amount:     b.amount,
+		Shard:      b.shard,
+		Nonce:      b.nonce,
+		Packed:     b.packed,
+	}
+
+	if b.tx != nil {
+		rec.Tx = b.tx.ToHex()
+	}
+
+	return rec
+}
+
+func (rec *accountRecord) toBalance() (*balance, error) {
+	key, err := crypto.LoadECDSAFromString(rec.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("load account record %s key: %s", rec.Address, err)
+	}
+
+	b := &balance{
+		address: crypto.GetAddress(&key.PublicKey),
+		signer:  NewECDSASigner(key),
+		amount:  rec.Amount,
+		shard:   rec.Shard,
+		nonce:   rec.Nonce,
+		packed:  rec.Packed,
+	}
+
+	if rec.Tx != "" {
+		hash := common.HexToHash(rec.Tx)
+		b.tx = &hash
+	}
+
+	return b, nil
+}
+
+// defaultStorePath places the account store next to keyFile, e.g.
+// keystore.txt -> keystore.txt.accountstore
+func defaultStorePath() string {
+	return filepath.Join(filepath.Dir(keyFile), filepath.Base(keyFile)+".accountstore")
+}
@@ -0,0 +1,130 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+func newTestBalance(t *testing.T) *balance {
+	t.Helper()
+
+	_, key := crypto.MustGenerateShardKeyPair(0)
+	addr := crypto.GetAddress(&key.PublicKey)
+	hash := common.StringToHash("test-tx")
+
+	return &balance{
+		address: addr,
+		signer:  NewECDSASigner(key),
+		amount:  42,
+		shard:   addr.Shard(),
+		nonce:   7,
+		tx:      &hash,
+		packed:  true,
+	}
+}
+
+func TestAccountRecordRoundTrip(t *testing.T) {
+	b := newTestBalance(t)
+
+	rec := newAccountRecord(b)
+	got, err := rec.toBalance()
+	if err != nil {
+		t.Fatalf("toBalance failed: %s", err)
+	}
+
+	if got.address.ToHex() != b.address.ToHex() {
+		t.Errorf("address = %s, want %s", got.address.ToHex(), b.address.ToHex())
+	}
+
+	if got.amount != b.amount {
+		t.Errorf("amount = %d, want %d", got.amount, b.amount)
+	}
+
+	if got.shard != b.shard {
+		t.Errorf("shard = %d, want %d", got.shard, b.shard)
+	}
+
+	if got.nonce != b.nonce {
+		t.Errorf("nonce = %d, want %d", got.nonce, b.nonce)
+	}
+
+	if got.packed != b.packed {
+		t.Errorf("packed = %v, want %v", got.packed, b.packed)
+	}
+
+	if got.tx == nil || got.tx.ToHex() != b.tx.ToHex() {
+		t.Errorf("tx = %v, want %s", got.tx, b.tx.ToHex())
+	}
+}
+
+func TestLevelDBAccountStoreSaveLoad(t *testing.T) {
+	store, err := openAccountStore(filepath.Join(t.TempDir(), "accounts"))
+	if err != nil {
+		t.Fatalf("openAccountStore failed: %s", err)
+	}
+	defer store.Close()
+
+	b := newTestBalance(t)
+	if err := store.Save(b); err != nil {
+		t.Fatalf("Save failed: %s", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %s", err)
+	}
+
+	if len(loaded) != 1 {
+		t.Fatalf("loaded %d accounts, want 1", len(loaded))
+	}
+
+	if loaded[0].address.ToHex() != b.address.ToHex() {
+		t.Errorf("loaded address = %s, want %s", loaded[0].address.ToHex(), b.address.ToHex())
+	}
+
+	if loaded[0].amount != b.amount {
+		t.Errorf("loaded amount = %d, want %d", loaded[0].amount, b.amount)
+	}
+}
+
+// nonExportableSigner is a Signer stand-in that, like ledgerSigner, can't
+// export a private key, so Save should skip it.
+type nonExportableSigner struct {
+	addr *common.Address
+}
+
+func (s *nonExportableSigner) Address() *common.Address { return s.addr }
+func (s *nonExportableSigner) SignTx(tx *types.Transaction) error { return nil }
+
+func TestLevelDBAccountStoreSaveSkipsNonExportableSigner(t *testing.T) {
+	store, err := openAccountStore(filepath.Join(t.TempDir(), "accounts"))
+	if err != nil {
+		t.Fatalf("openAccountStore failed: %s", err)
+	}
+	defer store.Close()
+
+	b := newTestBalance(t)
+	b.signer = &nonExportableSigner{addr: b.address}
+
+	if err := store.Save(b); err != nil {
+		t.Fatalf("Save failed: %s", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %s", err)
+	}
+
+	if len(loaded) != 0 {
+		t.Fatalf("loaded %d accounts, want 0 (ledger signer shouldn't persist)", len(loaded))
+	}
+}
@@ -0,0 +1,66 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/seeleteam/go-seele/cmd/util"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// shardWeights records the configured weight per shard, reported on
+// /metrics; it stays empty unless a --config file sets it. getRandClient
+// itself biases on weightedShards, the flattened form of this map.
+var shardWeights = make(map[uint]int)
+var weightedShards = make([]uint, 0)
+
+// applyConfigFile loads a SendtxConfig and overrides the equivalent
+// command-line flags and clientList with it, so a whole multi-shard testnet
+// topology can be described in one file instead of ad-hoc flags.
+func applyConfigFile(path string) {
+	cfg, err := util.GetConfigFromFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("load config file failed %s", err))
+	}
+
+	if cfg.KeyFile != "" {
+		keyFile = cfg.KeyFile
+	}
+
+	if cfg.TPS > 0 {
+		tps = cfg.TPS
+	}
+
+	if cfg.Workers > 0 {
+		workers = cfg.Workers
+	}
+
+	clientList = make(map[uint]*rpc.Client)
+	shardWeights = make(map[uint]int)
+	weightedShards = weightedShards[:0]
+
+	for _, shard := range cfg.Shards {
+		client, err := rpc.DialTCP(nil, shard.Endpoint)
+		if err != nil {
+			panic(fmt.Sprintf("dial shard %d endpoint %s failed %s", shard.Shard, shard.Endpoint, err))
+		}
+
+		clientList[shard.Shard] = client
+
+		weight := shard.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		shardWeights[shard.Shard] = weight
+
+		for i := 0; i < weight; i++ {
+			weightedShards = append(weightedShards, shard.Shard)
+		}
+	}
+
+	fmt.Printf("loaded config %s: %d shards, tps %d, workers %d\n", path, len(cfg.Shards), tps, workers)
+}
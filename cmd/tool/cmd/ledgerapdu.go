@@ -0,0 +1,115 @@
+// +build ledger
+
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/karalabe/hid"
+	"github.com/seeleteam/go-seele/common"
+)
+
+// APDU instruction codes and CLA/INS/P1/P2 framing for the Seele Ledger app.
+const (
+	ledgerCLA          = 0xe0
+	ledgerInsGetAddr   = 0x02
+	ledgerInsSignTx    = 0x04
+	ledgerHIDPacketLen = 64
+)
+
+// ledgerExchange frames data as a single 64-byte HID packet and returns the
+// payload of a single response packet. An APDU longer than the packet's 59
+// payload bytes is truncated; multi-packet exchanges are not supported.
+func ledgerExchange(device *hid.Device, ins byte, data []byte) ([]byte, error) {
+	apdu := append([]byte{ledgerCLA, ins, 0x00, 0x00, byte(len(data))}, data...)
+
+	packet := make([]byte, ledgerHIDPacketLen)
+	binary.BigEndian.PutUint16(packet[0:2], 0x0101) // channel id
+	packet[2] = 0x05                                // command tag
+	copy(packet[5:], apdu)
+
+	if _, err := device.Write(packet); err != nil {
+		return nil, fmt.Errorf("write apdu: %s", err)
+	}
+
+	resp := make([]byte, ledgerHIDPacketLen)
+	n, err := device.Read(resp)
+	if err != nil {
+		return nil, fmt.Errorf("read apdu response: %s", err)
+	}
+
+	if n < 7 {
+		return nil, fmt.Errorf("short ledger response")
+	}
+
+	return resp[5:n], nil
+}
+
+// ledgerDeriveAddress asks the device for the address at path without
+// requiring user confirmation, mirroring a non-confirming "get address" call.
+func ledgerDeriveAddress(device *hid.Device, path string) (*common.Address, error) {
+	resp, err := ledgerExchange(device, ledgerInsGetAddr, encodeDerivationPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	addr := common.BytesToAddress(resp)
+	return &addr, nil
+}
+
+// ledgerSignHash asks the device to sign hash with the key at path; the user
+// confirms the transaction on the device screen before it returns.
+func ledgerSignHash(device *hid.Device, path string, hash []byte) ([]byte, error) {
+	payload := append(encodeDerivationPath(path), hash...)
+	return ledgerExchange(device, ledgerInsSignTx, payload)
+}
+
+// encodeDerivationPath packs a "m/44'/617'/i'/0/0" style path into the
+// big-endian uint32-per-component wire format Ledger apps expect.
+func encodeDerivationPath(path string) []byte {
+	components := splitDerivationPath(path)
+
+	out := make([]byte, 0, 1+4*len(components))
+	out = append(out, byte(len(components)))
+	for _, c := range components {
+		buf := make([]byte, 4)
+		binary.BigEndian.PutUint32(buf, c)
+		out = append(out, buf...)
+	}
+
+	return out
+}
+
+// splitDerivationPath parses "m/44'/617'/0'/0/0" into [44+hardened, 617+hardened, 0+hardened, 0, 0].
+func splitDerivationPath(path string) []uint32 {
+	const hardened = 0x80000000
+
+	parts := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	components := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		harden := strings.HasSuffix(p, "'")
+		p = strings.TrimSuffix(p, "'")
+
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			panic(fmt.Sprintf("invalid derivation path %s: %s", path, err))
+		}
+
+		component := uint32(n)
+		if harden {
+			component |= hardened
+		}
+
+		components = append(components, component)
+	}
+
+	return components
+}
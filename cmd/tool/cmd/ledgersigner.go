@@ -0,0 +1,91 @@
+// +build ledger
+
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/karalabe/hid"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// ledgerVendorID and ledgerProductIDs identify the Ledger devices and
+// firmware apps sendtx knows how to talk to over USB HID.
+const ledgerVendorID = 0x2c97
+
+var ledgerProductIDs = []uint16{0x0001, 0x0004, 0x0005}
+
+// seeleDerivationPathFormat is the BIP-44-style path the Seele Ledger app
+// derives its keys from, with the account index substituted in.
+const seeleDerivationPathFormat = "m/44'/617'/%d'/0/0"
+
+// ledgerSigner signs by sending APDU commands to a Ledger device over USB
+// HID; the private key never leaves the hardware.
+type ledgerSigner struct {
+	device *hid.Device
+	path   string
+	addr   *common.Address
+}
+
+// EnumerateLedgerSigners opens every attached Ledger device and derives one
+// signer per device at seeleDerivationPathFormat, so initAccount can mix
+// hardware-backed accounts in alongside keyFile-loaded ones.
+func EnumerateLedgerSigners() ([]Signer, error) {
+	infos, err := hid.Enumerate(ledgerVendorID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("enumerate ledger devices: %s", err)
+	}
+
+	signers := make([]Signer, 0, len(infos))
+	for i, info := range infos {
+		if !isSeeleLedgerProduct(info.ProductID) {
+			continue
+		}
+
+		device, err := info.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open ledger device: %s", err)
+		}
+
+		path := fmt.Sprintf(seeleDerivationPathFormat, i)
+		addr, err := ledgerDeriveAddress(device, path)
+		if err != nil {
+			device.Close()
+			return nil, fmt.Errorf("derive ledger address: %s", err)
+		}
+
+		signers = append(signers, &ledgerSigner{device: device, path: path, addr: addr})
+	}
+
+	return signers, nil
+}
+
+func isSeeleLedgerProduct(productID uint16) bool {
+	for _, id := range ledgerProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *ledgerSigner) Address() *common.Address {
+	return s.addr
+}
+
+func (s *ledgerSigner) SignTx(tx *types.Transaction) error {
+	sig, err := ledgerSignHash(s.device, s.path, tx.Hash.Bytes())
+	if err != nil {
+		return fmt.Errorf("ledger sign: %s", err)
+	}
+
+	tx.Signature = sig
+	return nil
+}
@@ -0,0 +1,17 @@
+// +build !ledger
+
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import "fmt"
+
+// EnumerateLedgerSigners requires building with -tags ledger, which pulls in
+// the USB HID dependency; the default build keeps sendtx free of that
+// dependency for operators who never touch hardware wallets.
+func EnumerateLedgerSigners() ([]Signer, error) {
+	return nil, fmt.Errorf("ledger support not compiled in, rebuild with -tags ledger")
+}
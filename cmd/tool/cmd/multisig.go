@@ -0,0 +1,152 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/seeleteam/go-seele/cmd/util"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/spf13/cobra"
+)
+
+var multisigMode bool
+var multisigGroupFile string
+
+// runMultisigLoad repeatedly drives the propose/approve/execute flow for the
+// configured multisig group, so operators can stress-test an M-of-N
+// governance path instead of only single-signer transfers.
+func runMultisigLoad() {
+	group, err := util.LoadMultisigGroup(multisigGroupFile)
+	if err != nil {
+		panic(fmt.Sprintf("load multisig group failed %s", err))
+	}
+
+	executorKey, err := crypto.LoadECDSAFromString(group.Executor)
+	if err != nil {
+		panic(fmt.Sprintf("load multisig executor key failed %s", err))
+	}
+
+	signerKeys := make([]*ecdsa.PrivateKey, 0, len(group.Signers))
+	for _, hex := range group.Signers {
+		key, err := crypto.LoadECDSAFromString(hex)
+		if err != nil {
+			panic(fmt.Sprintf("load multisig signer key failed %s", err))
+		}
+		signerKeys = append(signerKeys, key)
+	}
+
+	executorAddr := crypto.GetAddress(&executorKey.PublicKey)
+	nonce := getNonce(*executorAddr)
+
+	count := 0
+	tpsStartTime := time.Now()
+	for {
+		to, _ := crypto.MustGenerateShardKeyPair(executorAddr.Shard())
+
+		value := big.NewInt(1)
+		value.Mul(value, common.SeeleToFan)
+
+		proposal := util.ProposeMultisigTx(to, value, big.NewInt(0), nonce)
+		for i := 0; i < group.Threshold && i < len(signerKeys); i++ {
+			if _, err := util.ApproveMultisigTx(group, proposal, signerKeys[i]); err != nil {
+				panic(fmt.Sprintf("approve multisig proposal failed %s", err))
+			}
+		}
+
+		client := getClient(*executorAddr)
+		tx, ok := util.ExecuteMultisigTx(client, group, executorKey, proposal)
+		if !ok {
+			fmt.Println("multisig proposal did not reach threshold or failed to submit, skipping")
+			continue
+		}
+
+		nonce++
+		fmt.Printf("multisig tx %s submitted, %d/%d approvals\n", tx.Hash.ToHex(), len(proposal.Approvals), group.Threshold)
+
+		count++
+		if count == tps {
+			elapse := time.Now().Sub(tpsStartTime)
+			if elapse < time.Second {
+				time.Sleep(time.Second - elapse)
+			}
+
+			count = 0
+			tpsStartTime = time.Now()
+		}
+	}
+}
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "manage the multisig signer group used by sendtx --multisig",
+}
+
+var addSignerCmd = &cobra.Command{
+	Use:   "add-signer <private-key-hex>",
+	Short: "add a signer to the multisig group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		group, err := util.LoadMultisigGroup(multisigGroupFile)
+		if err != nil {
+			panic(fmt.Sprintf("load multisig group failed %s", err))
+		}
+
+		group.Signers = append(group.Signers, args[0])
+		if err := util.SaveMultisigGroup(multisigGroupFile, group); err != nil {
+			panic(fmt.Sprintf("save multisig group failed %s", err))
+		}
+
+		fmt.Printf("added signer, group now has %d signers\n", len(group.Signers))
+	},
+}
+
+var removeSignerCmd = &cobra.Command{
+	Use:   "remove-signer <private-key-hex>",
+	Short: "remove a signer from the multisig group",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		group, err := util.LoadMultisigGroup(multisigGroupFile)
+		if err != nil {
+			panic(fmt.Sprintf("load multisig group failed %s", err))
+		}
+
+		remaining := group.Signers[:0]
+		for _, s := range group.Signers {
+			if s != args[0] {
+				remaining = append(remaining, s)
+			}
+		}
+		group.Signers = remaining
+
+		if group.Threshold > len(group.Signers) {
+			panic(fmt.Sprintf("removing this signer would drop the group below its threshold of %d", group.Threshold))
+		}
+
+		if err := util.SaveMultisigGroup(multisigGroupFile, group); err != nil {
+			panic(fmt.Sprintf("save multisig group failed %s", err))
+		}
+
+		fmt.Printf("removed signer, group now has %d signers\n", len(group.Signers))
+	},
+}
+
+func init() {
+	sendTxCmd.AddCommand(multisigCmd)
+	multisigCmd.AddCommand(addSignerCmd)
+	multisigCmd.AddCommand(removeSignerCmd)
+
+	sendTxCmd.Flags().BoolVarP(&multisigMode, "multisig", "", false, "drive the propose/approve/execute "+
+		"multisig flow instead of single-signer transfers")
+	sendTxCmd.Flags().StringVarP(&multisigGroupFile, "multisig-group", "", "multisig.json", "JSON file "+
+		"describing the multisig group's executor, signers and threshold")
+	multisigCmd.PersistentFlags().StringVarP(&multisigGroupFile, "multisig-group", "", "multisig.json",
+		"JSON file describing the multisig group's executor, signers and threshold")
+}
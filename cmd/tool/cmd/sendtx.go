@@ -6,7 +6,6 @@
 package cmd
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -26,19 +25,24 @@ import (
 var tps int
 var debug bool
 var onlytps bool
+var workers int
+var metricsAddr string
+var configFile string
+var useLedger bool
 
 var balanceList []*balance
 var balanceListLock sync.Mutex
 var wg = sync.WaitGroup{}
 
 type balance struct {
-	address    *common.Address
-	privateKey *ecdsa.PrivateKey
-	amount     int
-	shard      uint
-	nonce      uint64
-	tx         *common.Hash
-	packed     bool
+	mu      sync.Mutex
+	address *common.Address
+	signer  Signer
+	amount  int
+	shard   uint
+	nonce   uint64
+	tx      *common.Hash
+	packed  bool
 }
 
 var sendTxCmd = &cobra.Command{
@@ -47,11 +51,26 @@ var sendTxCmd = &cobra.Command{
 	Long: `For example:
 	tool.exe sendtx`,
 	Run: func(cmd *cobra.Command, args []string) {
-		initClient()
+		if configFile != "" {
+			applyConfigFile(configFile)
+		} else {
+			initClient()
+		}
+
+		if vectorsDir != "" {
+			runVectors(vectorsDir)
+			return
+		}
+
+		if multisigMode {
+			runMultisigLoad()
+			return
+		}
+
 		balanceList = initAccount()
 
 		wg.Add(1)
-		go loopSend()
+		go runSendPool(workers)
 
 		if !onlytps {
 			wg.Add(1)
@@ -64,50 +83,6 @@ var sendTxCmd = &cobra.Command{
 
 var txCh = make(chan *balance, 100000)
 
-func loopSend() {
-	defer wg.Done()
-	count := 0
-	tpsStartTime := time.Now()
-
-	// send tx periodically
-	for {
-		balanceListLock.Lock()
-		copyBalances := make([]*balance, len(balanceList))
-		copy(copyBalances, balanceList)
-		fmt.Printf("balance total length %d\n", len(balanceList))
-		balanceListLock.Unlock()
-
-		for _, b := range copyBalances {
-			newBalance := send(b)
-			if newBalance.amount > 0 {
-				txCh <- newBalance
-			}
-
-			count++
-			if count == tps {
-				fmt.Println("send txs ", count)
-				elapse := time.Now().Sub(tpsStartTime)
-				if elapse < time.Second {
-					time.Sleep(time.Second - elapse)
-				}
-
-				count = 0
-				tpsStartTime = time.Now()
-			}
-		}
-
-		balanceListLock.Lock()
-		nextBalanceList := make([]*balance, 0)
-		for _, b := range balanceList {
-			if b.amount > 0 {
-				nextBalanceList = append(nextBalanceList, b)
-			}
-		}
-		balanceList = nextBalanceList
-		balanceListLock.Unlock()
-	}
-}
-
 func loopCheck() {
 	defer wg.Done()
 	toPackedBalanceList := make([]*balance, 0)
@@ -137,6 +112,14 @@ func loopCheck() {
 					fmt.Printf("add confirmed balance %d, new: %d\n", len(value), len(balanceList))
 					balanceListLock.Unlock()
 
+					if accountStore != nil {
+						for _, b := range value {
+							if err := accountStore.Save(b); err != nil {
+								fmt.Printf("persist confirmed balance %s failed %s\n", b.address.ToHex(), err)
+							}
+						}
+					}
+
 					delete(toConfirmBalanceList, key)
 				}
 			}
@@ -179,6 +162,14 @@ func getTx(address common.Address, hash common.Hash) map[string]interface{} {
 }
 
 func send(b *balance) *balance {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.amount <= 0 {
+		// Already drained by a prior send; nothing left to do.
+		return &balance{address: b.address, amount: 0}
+	}
+
 	var amount = 1
 	if !onlytps {
 		amount = rand.Intn(b.amount) // for test, amount will always keep in int value.
@@ -186,24 +177,35 @@ func send(b *balance) *balance {
 
 	addr, privateKey := crypto.MustGenerateShardKeyPair(b.address.Shard())
 	newBalance := &balance{
-		address:    addr,
-		privateKey: privateKey,
-		amount:     amount,
-		shard:      addr.Shard(),
-		nonce:      0,
-		packed:     false,
+		address: addr,
+		signer:  NewECDSASigner(privateKey),
+		amount:  amount,
+		shard:   addr.Shard(),
+		nonce:   0,
+		packed:  false,
 	}
 
 	value := big.NewInt(int64(amount))
 	value.Mul(value, common.SeeleToFan)
 
+	tx := util.NewUnsignedTx(*b.address, *addr, value, big.NewInt(0), b.nonce, nil)
+	if err := b.signer.SignTx(tx); err != nil {
+		fmt.Printf("sign tx for %s failed %s\n", b.address.ToHex(), err)
+		return newBalance
+	}
+
 	client := getRandClient()
-	tx, ok := util.Sendtx(client, b.privateKey, addr, value, big.NewInt(0), b.nonce, nil)
-	if ok {
+	if util.SubmitSignedTx(client, tx) {
 		// update balance by transaction amount and update nonce
 		b.nonce++
 		b.amount -= amount
 		newBalance.tx = &tx.Hash
+
+		if accountStore != nil {
+			if err := accountStore.Save(b); err != nil {
+				fmt.Printf("persist sender balance %s failed %s\n", b.address.ToHex(), err)
+			}
+		}
 	}
 
 	return newBalance
@@ -214,6 +216,13 @@ func getRandClient() *rpc.Client {
 		panic("no client found")
 	}
 
+	if len(weightedShards) > 0 {
+		shard := weightedShards[rand.Intn(len(weightedShards))]
+		if client, ok := clientList[shard]; ok {
+			return client
+		}
+	}
+
 	index := rand.Intn(len(clientList))
 
 	count := 0
@@ -229,6 +238,53 @@ func getRandClient() *rpc.Client {
 }
 
 func initAccount() []*balance {
+	path := storeFile
+	if path == "" {
+		path = defaultStorePath()
+	}
+
+	store, err := openAccountStore(path)
+	if err != nil {
+		panic(fmt.Sprintf("open account store failed %s", err))
+	}
+	accountStore = store
+
+	resumed, err := accountStore.Load()
+	if err != nil {
+		panic(fmt.Sprintf("load account store failed %s", err))
+	}
+
+	var balanceList []*balance
+	if len(resumed) > 0 {
+		for _, b := range resumed {
+			if b.amount > 0 {
+				balanceList = append(balanceList, b)
+			}
+		}
+		fmt.Printf("resumed %d accounts from account store %s, %d with balance left\n", len(resumed), path, len(balanceList))
+	} else {
+		balanceList = scanAccountsFromKeyFile()
+		for _, b := range balanceList {
+			if err := accountStore.Save(b); err != nil {
+				panic(fmt.Sprintf("migrate account into store failed %s", err))
+			}
+		}
+	}
+
+	// Ledger signers are never persisted to the account store (they can't
+	// export a private key to save), so they must be re-enumerated on every
+	// run regardless of whether the rest of the list was resumed.
+	if useLedger {
+		balanceList = append(balanceList, scanLedgerAccounts()...)
+	}
+
+	return balanceList
+}
+
+// scanAccountsFromKeyFile is the original, RPC-driven account discovery: it
+// reads every key out of keyFile and looks its balance/nonce up over RPC.
+// It only runs once, the first time a given account store is populated.
+func scanAccountsFromKeyFile() []*balance {
 	balanceList := make([]*balance, 0)
 
 	keys, err := ioutil.ReadFile(keyFile)
@@ -261,11 +317,11 @@ func initAccount() []*balance {
 		}
 
 		b := &balance{
-			address:    addr,
-			privateKey: key,
-			amount:     amount,
-			shard:      addr.Shard(),
-			packed:     false,
+			address: addr,
+			signer:  NewECDSASigner(key),
+			amount:  amount,
+			shard:   addr.Shard(),
+			packed:  false,
 		}
 
 		fmt.Printf("%s balance is %d\n", b.address.ToHex(), b.amount)
@@ -279,6 +335,42 @@ func initAccount() []*balance {
 	return balanceList
 }
 
+// scanLedgerAccounts enumerates Ledger-derived addresses so they can be
+// driven by the same load alongside file-loaded keys.
+func scanLedgerAccounts() []*balance {
+	signers, err := EnumerateLedgerSigners()
+	if err != nil {
+		panic(fmt.Sprintf("enumerate ledger signers failed %s", err))
+	}
+
+	balanceList := make([]*balance, 0, len(signers))
+	for _, signer := range signers {
+		addr := signer.Address()
+		if _, ok := clientList[addr.Shard()]; !ok {
+			continue
+		}
+
+		amount, ok := getBalance(*addr)
+		if !ok || amount <= 0 {
+			continue
+		}
+
+		b := &balance{
+			address: addr,
+			signer:  signer,
+			amount:  amount,
+			shard:   addr.Shard(),
+			nonce:   getNonce(*addr),
+			packed:  false,
+		}
+
+		fmt.Printf("ledger %s balance is %d\n", b.address.ToHex(), b.amount)
+		balanceList = append(balanceList, b)
+	}
+
+	return balanceList
+}
+
 func getBalance(address common.Address) (int, bool) {
 	client := getClient(address)
 
@@ -326,4 +418,14 @@ func init() {
 	sendTxCmd.Flags().BoolVarP(&debug, "debug", "d", false, "whether print more debug info")
 	sendTxCmd.Flags().BoolVarP(&onlytps, "onlytps", "", false, "only tps will stop balance update "+
 		"and transfer only 1 Seele at a time. This is used for large tps test.")
+	sendTxCmd.Flags().StringVarP(&vectorsDir, "vectors", "", "", "directory of deterministic tx test "+
+		"vectors to replay instead of random sends; writes a JUnit report.xml into the same directory")
+	sendTxCmd.Flags().IntVarP(&workers, "workers", "", 4, "number of concurrent workers sending transactions")
+	sendTxCmd.Flags().StringVarP(&metricsAddr, "metrics", "", ":8090", "address to serve live /metrics on")
+	sendTxCmd.Flags().StringVarP(&configFile, "config", "c", "", "JSON config file describing RPC endpoints, "+
+		"key file, tps and per-shard weights; overrides the equivalent flags when set")
+	sendTxCmd.Flags().StringVarP(&storeFile, "store", "", "", "account store directory, defaults to "+
+		"<keyfile>.accountstore; resumes balances/nonces across crashes instead of re-scanning keyFile")
+	sendTxCmd.Flags().BoolVarP(&useLedger, "ledger", "", false, "also drive accounts derived from an "+
+		"attached Ledger device (requires building with -tags ledger)")
 }
@@ -0,0 +1,61 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+// Signer abstracts the signing step of send() so sendtx can drive either an
+// in-memory ECDSA key or a hardware wallet through the same path, without
+// ever loading a hardware-protected secret into the process.
+type Signer interface {
+	Address() *common.Address
+	SignTx(tx *types.Transaction) error
+}
+
+// exportableSigner is implemented by signers whose private key can be
+// persisted, e.g. by the account store. Hardware signers deliberately don't
+// implement it.
+type exportableSigner interface {
+	PrivateKeyHex() string
+}
+
+// ecdsaSigner signs with an in-memory private key loaded from keyFile.
+type ecdsaSigner struct {
+	key  *ecdsa.PrivateKey
+	addr *common.Address
+}
+
+// NewECDSASigner wraps an in-memory private key as a Signer.
+func NewECDSASigner(key *ecdsa.PrivateKey) Signer {
+	return &ecdsaSigner{
+		key:  key,
+		addr: crypto.GetAddress(&key.PublicKey),
+	}
+}
+
+func (s *ecdsaSigner) Address() *common.Address {
+	return s.addr
+}
+
+func (s *ecdsaSigner) SignTx(tx *types.Transaction) error {
+	sig, err := crypto.Sign(s.key, tx.Hash.Bytes())
+	if err != nil {
+		return err
+	}
+
+	tx.Signature = sig
+	return nil
+}
+
+func (s *ecdsaSigner) PrivateKeyHex() string {
+	return common.BytesToHex(crypto.FromECDSA(s.key))
+}
@@ -0,0 +1,173 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/seeleteam/go-seele/cmd/util"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+var vectorsDir string
+
+// txVector describes one deterministic test-vector: the transaction to send
+// and the observable outcome it is expected to produce.
+type txVector struct {
+	Name            string `json:"name"`
+	SenderKey       string `json:"senderKey"`
+	Recipient       string `json:"recipient"`
+	Nonce           uint64 `json:"nonce"`
+	Amount          int64  `json:"amount"`
+	Fee             int64  `json:"fee"`
+	ExpectedTxHash  string `json:"expectedTxHash"`
+	ExpectedBalance int64  `json:"expectedBalance"`
+	ExpectedNonce   uint64 `json:"expectedNonce"`
+}
+
+// junitTestSuite and junitTestCase follow the de-facto JUnit XML schema so the
+// report can be consumed by CI systems without a Seele-specific parser.
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string       `xml:"name,attr"`
+	Time    float64      `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// runVectors replays every test vector found in dir against the configured
+// RPC clients and writes a JUnit-style report to <dir>/report.xml. It is the
+// deterministic counterpart to the random runSendPool/send path used by the
+// onlytps load test.
+func runVectors(dir string) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		panic(fmt.Sprintf("read vectors dir failed %s", err))
+	}
+
+	suite := junitTestSuite{Name: "sendtx-vectors"}
+
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+
+		start := time.Now()
+		name := f.Name()
+		tc := junitTestCase{Name: name}
+
+		if err := runVector(filepath.Join(dir, name)); err != nil {
+			tc.Failure = &junitFailure{Message: err.Error(), Text: err.Error()}
+			suite.Failures++
+		}
+
+		tc.Time = time.Since(start).Seconds()
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	report, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("marshal vectors report failed %s", err))
+	}
+
+	reportPath := filepath.Join(dir, "report.xml")
+	if err := ioutil.WriteFile(reportPath, report, 0644); err != nil {
+		panic(fmt.Sprintf("write vectors report failed %s", err))
+	}
+
+	fmt.Printf("ran %d vectors, %d failed, report written to %s\n", suite.Tests, suite.Failures, reportPath)
+}
+
+// runVector signs and submits a single vector, polls until the transaction is
+// included, and diffs the observed hash and post-state against the vector.
+func runVector(path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read vector: %s", err)
+	}
+
+	var v txVector
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Errorf("parse vector: %s", err)
+	}
+
+	key, err := crypto.LoadECDSAFromString(v.SenderKey)
+	if err != nil {
+		return fmt.Errorf("load sender key: %s", err)
+	}
+
+	sender := crypto.GetAddress(&key.PublicKey)
+	if v.Recipient == "" {
+		return fmt.Errorf("vector has no recipient address")
+	}
+	recipient := common.HexToAddress(v.Recipient)
+
+	client := getClient(*sender)
+	value := big.NewInt(v.Amount)
+	value.Mul(value, common.SeeleToFan)
+	fee := big.NewInt(v.Fee)
+
+	tx, ok := util.Sendtx(client, key, &recipient, value, fee, v.Nonce, nil)
+	if !ok {
+		return fmt.Errorf("submit tx failed")
+	}
+
+	if v.ExpectedTxHash != "" && tx.Hash.ToHex() != v.ExpectedTxHash {
+		return fmt.Errorf("tx hash mismatch: want %s got %s", v.ExpectedTxHash, tx.Hash.ToHex())
+	}
+
+	if err := waitIncluded(*sender, tx.Hash); err != nil {
+		return err
+	}
+
+	amount, _ := getBalance(*sender)
+	if int64(amount) != v.ExpectedBalance {
+		return fmt.Errorf("balance mismatch: want %d got %d", v.ExpectedBalance, amount)
+	}
+
+	nonce := getNonce(*sender)
+	if nonce != v.ExpectedNonce {
+		return fmt.Errorf("nonce mismatch: want %d got %d", v.ExpectedNonce, nonce)
+	}
+
+	return nil
+}
+
+// waitIncluded polls txpool.GetTransactionByHash until the transaction is
+// packed into a block or the poll times out.
+func waitIncluded(address common.Address, hash common.Hash) error {
+	deadline := time.Now().Add(2 * time.Minute)
+	for time.Now().Before(deadline) {
+		result := getTx(address, hash)
+		if result["status"] == "block" {
+			return nil
+		}
+
+		time.Sleep(time.Second)
+	}
+
+	return fmt.Errorf("tx %s not included before deadline", hash.ToHex())
+}
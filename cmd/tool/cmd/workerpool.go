@@ -0,0 +1,285 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sendJob is a unit of work handed from the dispatcher to a pool worker.
+type sendJob struct {
+	b *balance
+}
+
+var jobCh = make(chan sendJob, 10000)
+
+// inFlight tracks balances that currently have a job outstanding in jobCh or
+// being processed by a worker, so dispatchBalances doesn't enqueue a second
+// job for the same balance before the first one finishes.
+var (
+	inFlightLock sync.Mutex
+	inFlight     = make(map[*balance]bool)
+)
+
+// poolMetrics tracks the live counters exposed on the /metrics endpoint.
+var poolMetrics = struct {
+	inFlight int64
+	failed   int64
+	retries  int64
+
+	shardLock sync.Mutex
+	shardTx   map[uint]int64 // total sent per shard, used to derive tx/sec
+}{
+	shardTx: make(map[uint]int64),
+}
+
+// shardBackoff tracks the exponential backoff state for a single shard's RPC
+// client after repeated send failures.
+type shardBackoff struct {
+	failures int
+	until    time.Time
+}
+
+var (
+	backoffLock sync.Mutex
+	backoffs    = make(map[uint]*shardBackoff)
+)
+
+const (
+	maxRetries  = 5
+	baseBackoff = time.Second
+	maxBackoff  = 30 * time.Second
+)
+
+// runSendPool replaces the single-goroutine loopSend with a bounded pool of
+// n workers that sign and submit transactions in parallel. A panicking or
+// erroring worker is restarted automatically; a shard that keeps failing is
+// backed off exponentially instead of being hammered.
+func runSendPool(n int) {
+	defer wg.Done()
+
+	if n < 1 {
+		n = 1
+	}
+
+	if metricsAddr != "" {
+		go startMetricsServer(metricsAddr)
+	}
+
+	var poolWg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		poolWg.Add(1)
+		go runWorker(i, &poolWg)
+	}
+
+	go dispatchBalances()
+
+	poolWg.Wait()
+}
+
+// dispatchBalances periodically snapshots balanceList and feeds it to the
+// worker pool via jobCh, mirroring the original loopSend scheduling loop.
+func dispatchBalances() {
+	count := 0
+	tpsStartTime := time.Now()
+
+	for {
+		balanceListLock.Lock()
+		copyBalances := make([]*balance, len(balanceList))
+		copy(copyBalances, balanceList)
+		fmt.Printf("balance total length %d\n", len(balanceList))
+		balanceListLock.Unlock()
+
+		for _, b := range copyBalances {
+			inFlightLock.Lock()
+			if inFlight[b] {
+				inFlightLock.Unlock()
+				continue
+			}
+			inFlight[b] = true
+			inFlightLock.Unlock()
+
+			jobCh <- sendJob{b: b}
+
+			count++
+			if count == tps {
+				fmt.Println("send txs ", count)
+				elapse := time.Now().Sub(tpsStartTime)
+				if elapse < time.Second {
+					time.Sleep(time.Second - elapse)
+				}
+
+				count = 0
+				tpsStartTime = time.Now()
+			}
+		}
+
+		balanceListLock.Lock()
+		nextBalanceList := make([]*balance, 0)
+		for _, b := range balanceList {
+			b.mu.Lock()
+			amount := b.amount
+			b.mu.Unlock()
+
+			if amount > 0 {
+				nextBalanceList = append(nextBalanceList, b)
+			}
+		}
+		balanceList = nextBalanceList
+		balanceListLock.Unlock()
+	}
+}
+
+// runWorker drains jobCh until the process exits, restarting itself whenever
+// the job handler panics so a single bad RPC call can't take the pool down.
+func runWorker(id int, poolWg *sync.WaitGroup) {
+	defer poolWg.Done()
+
+	for job := range jobCh {
+		handleJob(id, job)
+	}
+}
+
+// handleJob processes a single job with panic recovery, retrying with
+// exponential shard backoff on failure. It always clears job.b from inFlight
+// before returning, whether it succeeds, gives up, or panics, so the next
+// dispatch round can pick the balance back up instead of it being stuck.
+func handleJob(id int, job sendJob) {
+	defer func() {
+		inFlightLock.Lock()
+		delete(inFlight, job.b)
+		inFlightLock.Unlock()
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("worker %d recovered from panic: %v\n", id, r)
+			atomic.AddInt64(&poolMetrics.failed, 1)
+			recordBackoff(job.b.shard)
+		}
+	}()
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if balanceAmount(job.b) <= 0 {
+			return
+		}
+
+		waitForShard(job.b.shard)
+
+		atomic.AddInt64(&poolMetrics.inFlight, 1)
+		newBalance := send(job.b)
+		atomic.AddInt64(&poolMetrics.inFlight, -1)
+
+		if newBalance.tx == nil && balanceAmount(job.b) > 0 {
+			// send() leaves tx nil when the RPC submit failed.
+			atomic.AddInt64(&poolMetrics.failed, 1)
+			atomic.AddInt64(&poolMetrics.retries, 1)
+			recordBackoff(job.b.shard)
+			continue
+		}
+
+		clearBackoff(job.b.shard)
+		recordShardTx(job.b.shard)
+
+		if newBalance.amount > 0 {
+			txCh <- newBalance
+		}
+
+		return
+	}
+
+	fmt.Printf("worker %d giving up on shard %d after %d retries\n", id, job.b.shard, maxRetries)
+}
+
+// balanceAmount reads b.amount under its own lock, since workers and the
+// dispatcher both touch it concurrently.
+func balanceAmount(b *balance) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.amount
+}
+
+func waitForShard(shard uint) {
+	backoffLock.Lock()
+	bo, ok := backoffs[shard]
+	backoffLock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if wait := time.Until(bo.until); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func recordBackoff(shard uint) {
+	backoffLock.Lock()
+	defer backoffLock.Unlock()
+
+	bo, ok := backoffs[shard]
+	if !ok {
+		bo = &shardBackoff{}
+		backoffs[shard] = bo
+	}
+
+	bo.failures++
+
+	// Clamp the shift so a long failure streak can't overflow the duration.
+	shift := uint(bo.failures - 1)
+	if shift > 30 {
+		shift = 30
+	}
+	delay := baseBackoff * time.Duration(1<<shift)
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	bo.until = time.Now().Add(delay)
+}
+
+func clearBackoff(shard uint) {
+	backoffLock.Lock()
+	delete(backoffs, shard)
+	backoffLock.Unlock()
+}
+
+func recordShardTx(shard uint) {
+	poolMetrics.shardLock.Lock()
+	poolMetrics.shardTx[shard]++
+	poolMetrics.shardLock.Unlock()
+}
+
+// startMetricsServer exposes in-flight, failed, retry and per-shard tx
+// counters over HTTP so operators can watch a load run scale toward the
+// --tps target in real time.
+func startMetricsServer(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "inflight %d\n", atomic.LoadInt64(&poolMetrics.inFlight))
+		fmt.Fprintf(w, "failed %d\n", atomic.LoadInt64(&poolMetrics.failed))
+		fmt.Fprintf(w, "retries %d\n", atomic.LoadInt64(&poolMetrics.retries))
+
+		poolMetrics.shardLock.Lock()
+		defer poolMetrics.shardLock.Unlock()
+		for shard, count := range poolMetrics.shardTx {
+			fmt.Fprintf(w, "shard_%d_tx %d\n", shard, count)
+		}
+
+		for shard, weight := range shardWeights {
+			fmt.Fprintf(w, "shard_%d_weight %d\n", shard, weight)
+		}
+	})
+
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		fmt.Printf("metrics server stopped: %s\n", err)
+	}
+}
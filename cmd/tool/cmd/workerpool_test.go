@@ -0,0 +1,100 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func resetBackoffs() {
+	backoffLock.Lock()
+	backoffs = make(map[uint]*shardBackoff)
+	backoffLock.Unlock()
+}
+
+func TestRecordBackoffGrowsExponentially(t *testing.T) {
+	resetBackoffs()
+	defer resetBackoffs()
+
+	const shard = uint(3)
+
+	recordBackoff(shard)
+	backoffLock.Lock()
+	first := backoffs[shard].until
+	backoffLock.Unlock()
+
+	recordBackoff(shard)
+	backoffLock.Lock()
+	second := backoffs[shard].until
+	failures := backoffs[shard].failures
+	backoffLock.Unlock()
+
+	if failures != 2 {
+		t.Errorf("failures = %d, want 2", failures)
+	}
+
+	if !second.After(first) {
+		t.Errorf("second backoff deadline %v should be later than the first %v", second, first)
+	}
+}
+
+func TestRecordBackoffCapsAtMaxBackoff(t *testing.T) {
+	resetBackoffs()
+	defer resetBackoffs()
+
+	const shard = uint(4)
+
+	for i := 0; i < 20; i++ {
+		recordBackoff(shard)
+	}
+
+	backoffLock.Lock()
+	until := backoffs[shard].until
+	backoffLock.Unlock()
+
+	if wait := time.Until(until); wait > maxBackoff {
+		t.Errorf("backoff wait %v exceeds maxBackoff %v", wait, maxBackoff)
+	}
+}
+
+func TestClearBackoffRemovesShard(t *testing.T) {
+	resetBackoffs()
+	defer resetBackoffs()
+
+	const shard = uint(5)
+
+	recordBackoff(shard)
+	clearBackoff(shard)
+
+	backoffLock.Lock()
+	_, ok := backoffs[shard]
+	backoffLock.Unlock()
+
+	if ok {
+		t.Error("clearBackoff left an entry behind")
+	}
+}
+
+func TestWaitForShardReturnsImmediatelyWithoutBackoff(t *testing.T) {
+	resetBackoffs()
+	defer resetBackoffs()
+
+	start := time.Now()
+	waitForShard(6)
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("waitForShard with no recorded backoff took %v, want near-instant", elapsed)
+	}
+}
+
+func TestBalanceAmountReadsUnderLock(t *testing.T) {
+	b := &balance{amount: 9}
+
+	if got := balanceAmount(b); got != 9 {
+		t.Errorf("balanceAmount = %d, want 9", got)
+	}
+}
@@ -0,0 +1,52 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// ShardEndpoint maps a single shard to the RPC endpoint that serves it, with
+// an optional weight used to bias how often a shard is picked for sends.
+type ShardEndpoint struct {
+	Shard    uint   `json:"shard"`
+	Endpoint string `json:"endpoint"`
+	Weight   int    `json:"weight"`
+}
+
+// SendtxConfig describes the topology and target load for a sendtx run: the
+// shard->endpoint mapping, the key file to load accounts from, and the
+// overall TPS target.
+type SendtxConfig struct {
+	KeyFile string          `json:"keyFile"`
+	TPS     int             `json:"tps"`
+	Workers int             `json:"workers"`
+	Shards  []ShardEndpoint `json:"shards"`
+}
+
+// GetConfigFromFile loads a SendtxConfig from a JSON file, so a multi-shard
+// testnet topology can be described once and checked into version control
+// alongside its test-vector corpora instead of being wired through ad-hoc
+// command-line flags.
+func GetConfigFromFile(path string) (*SendtxConfig, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %s", err)
+	}
+
+	var cfg SendtxConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config file: %s", err)
+	}
+
+	if len(cfg.Shards) == 0 {
+		return nil, fmt.Errorf("config file %s defines no shards", path)
+	}
+
+	return &cfg, nil
+}
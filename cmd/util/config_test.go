@@ -0,0 +1,78 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package util
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("write config file: %s", err)
+	}
+
+	return path
+}
+
+func TestGetConfigFromFile(t *testing.T) {
+	path := writeConfigFile(t, `{
+		"keyFile": "keystore.txt",
+		"tps": 10,
+		"workers": 2,
+		"shards": [
+			{"shard": 1, "endpoint": "127.0.0.1:8027", "weight": 2},
+			{"shard": 2, "endpoint": "127.0.0.1:8028"}
+		]
+	}`)
+
+	cfg, err := GetConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("GetConfigFromFile failed: %s", err)
+	}
+
+	if cfg.KeyFile != "keystore.txt" {
+		t.Errorf("KeyFile = %q, want keystore.txt", cfg.KeyFile)
+	}
+
+	if cfg.TPS != 10 {
+		t.Errorf("TPS = %d, want 10", cfg.TPS)
+	}
+
+	if cfg.Workers != 2 {
+		t.Errorf("Workers = %d, want 2", cfg.Workers)
+	}
+
+	if len(cfg.Shards) != 2 {
+		t.Fatalf("len(Shards) = %d, want 2", len(cfg.Shards))
+	}
+
+	if cfg.Shards[0].Weight != 2 {
+		t.Errorf("Shards[0].Weight = %d, want 2", cfg.Shards[0].Weight)
+	}
+
+	if cfg.Shards[1].Weight != 0 {
+		t.Errorf("Shards[1].Weight = %d, want 0 (no weight in file)", cfg.Shards[1].Weight)
+	}
+}
+
+func TestGetConfigFromFileRejectsNoShards(t *testing.T) {
+	path := writeConfigFile(t, `{"keyFile": "keystore.txt", "tps": 10}`)
+
+	if _, err := GetConfigFromFile(path); err == nil {
+		t.Fatal("expected an error for a config with no shards, got nil")
+	}
+}
+
+func TestGetConfigFromFileRejectsMissingFile(t *testing.T) {
+	if _, err := GetConfigFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected an error for a missing config file, got nil")
+	}
+}
@@ -0,0 +1,194 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// MultisigGroup is a client-side M-of-N approval gate guarding a single
+// on-chain executor account: once Threshold of Signers have approved a
+// proposal, Executor signs and submits the real transaction.
+type MultisigGroup struct {
+	Threshold int      `json:"threshold"`
+	Executor  string   `json:"executor"`
+	Signers   []string `json:"signers"`
+}
+
+// MultisigProposal is a pending M-of-N transfer awaiting approvals.
+type MultisigProposal struct {
+	To        common.Address `json:"to"`
+	Amount    *big.Int       `json:"amount"`
+	Fee       *big.Int       `json:"fee"`
+	Nonce     uint64         `json:"nonce"`
+	Approvals []Approval     `json:"approvals"`
+}
+
+// Approval is one signer's signature over a MultisigProposal's digest.
+type Approval struct {
+	Signer    string          `json:"signer"`
+	Signature json.RawMessage `json:"signature"`
+}
+
+// LoadMultisigGroup reads a MultisigGroup from a JSON file.
+func LoadMultisigGroup(path string) (*MultisigGroup, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read multisig group: %s", err)
+	}
+
+	var group MultisigGroup
+	if err := json.Unmarshal(raw, &group); err != nil {
+		return nil, fmt.Errorf("parse multisig group: %s", err)
+	}
+
+	return &group, nil
+}
+
+// SaveMultisigGroup writes group to path as JSON.
+func SaveMultisigGroup(path string, group *MultisigGroup) error {
+	raw, err := json.MarshalIndent(group, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode multisig group: %s", err)
+	}
+
+	return ioutil.WriteFile(path, raw, 0644)
+}
+
+// ProposeMultisigTx starts a new proposal awaiting approvals.
+func ProposeMultisigTx(to *common.Address, amount, fee *big.Int, nonce uint64) *MultisigProposal {
+	return &MultisigProposal{
+		To:     *to,
+		Amount: amount,
+		Fee:    fee,
+		Nonce:  nonce,
+	}
+}
+
+// ApproveMultisigTx has signer co-sign proposal's digest, recording its
+// address and signature as an approval, and returns the updated approval
+// count. signer must belong to group.Signers; a key outside the group can't
+// approve, and the same signer can't be counted twice.
+func ApproveMultisigTx(group *MultisigGroup, proposal *MultisigProposal, signer *ecdsa.PrivateKey) (int, error) {
+	addr := crypto.GetAddress(&signer.PublicKey)
+	if !isGroupSigner(group, addr.ToHex()) {
+		return 0, fmt.Errorf("%s is not a signer in this multisig group", addr.ToHex())
+	}
+
+	for _, a := range proposal.Approvals {
+		if a.Signer == addr.ToHex() {
+			return len(proposal.Approvals), nil
+		}
+	}
+
+	sig, err := crypto.Sign(signer, proposalDigest(proposal))
+	if err != nil {
+		return 0, fmt.Errorf("sign proposal: %s", err)
+	}
+
+	sigBytes, err := json.Marshal(sig)
+	if err != nil {
+		return 0, fmt.Errorf("encode approval signature: %s", err)
+	}
+
+	proposal.Approvals = append(proposal.Approvals, Approval{Signer: addr.ToHex(), Signature: sigBytes})
+
+	return len(proposal.Approvals), nil
+}
+
+// isGroupSigner reports whether addr is the address derived from one of
+// group.Signers' private keys.
+func isGroupSigner(group *MultisigGroup, addr string) bool {
+	for _, hex := range group.Signers {
+		key, err := crypto.LoadECDSAFromString(hex)
+		if err != nil {
+			continue
+		}
+
+		if crypto.GetAddress(&key.PublicKey).ToHex() == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proposalDigest hashes the fields that define the transfer a proposal's
+// approvals are signing over.
+func proposalDigest(p *MultisigProposal) []byte {
+	raw, err := json.Marshal(struct {
+		To     common.Address
+		Amount *big.Int
+		Fee    *big.Int
+		Nonce  uint64
+	}{p.To, p.Amount, p.Fee, p.Nonce})
+	if err != nil {
+		panic(fmt.Sprintf("encode proposal digest failed %s", err))
+	}
+
+	return crypto.Keccak256(raw)
+}
+
+// ExecuteMultisigTx submits proposal once it has reached group.Threshold
+// distinct, verified approvals, signing with the group's executor key and
+// embedding the collected approvals in the transaction payload so they
+// remain auditable. An approval only counts if its signature actually
+// verifies against the proposal digest for the address it claims to be
+// from, and that address belongs to the group - a hand-built proposal with
+// forged approvals can't reach the threshold this way.
+func ExecuteMultisigTx(client *rpc.Client, group *MultisigGroup, executor *ecdsa.PrivateKey, proposal *MultisigProposal) (*types.Transaction, bool) {
+	digest := proposalDigest(proposal)
+
+	verified := make(map[string]bool, len(proposal.Approvals))
+	for _, a := range proposal.Approvals {
+		if !isGroupSigner(group, a.Signer) {
+			continue
+		}
+
+		ok, err := verifyApproval(a, digest)
+		if err != nil || !ok {
+			continue
+		}
+
+		verified[a.Signer] = true
+	}
+
+	if len(verified) < group.Threshold {
+		return nil, false
+	}
+
+	payload, err := json.Marshal(proposal.Approvals)
+	if err != nil {
+		return nil, false
+	}
+
+	return Sendtx(client, executor, &proposal.To, proposal.Amount, proposal.Fee, proposal.Nonce, payload)
+}
+
+// verifyApproval reports whether a's signature is a valid signature over
+// digest by the address it claims (a.Signer).
+func verifyApproval(a Approval, digest []byte) (bool, error) {
+	var sig []byte
+	if err := json.Unmarshal(a.Signature, &sig); err != nil {
+		return false, fmt.Errorf("decode approval signature: %s", err)
+	}
+
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return false, fmt.Errorf("recover approval signer: %s", err)
+	}
+
+	return crypto.GetAddress(pub).ToHex() == a.Signer, nil
+}
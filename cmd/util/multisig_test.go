@@ -0,0 +1,116 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+func newTestGroup(t *testing.T, n int) (*MultisigGroup, []*ecdsa.PrivateKey) {
+	t.Helper()
+
+	group := &MultisigGroup{Threshold: n}
+	keys := make([]*ecdsa.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		_, key := crypto.MustGenerateShardKeyPair(0)
+		keys = append(keys, key)
+		group.Signers = append(group.Signers, common.BytesToHex(crypto.FromECDSA(key)))
+	}
+
+	return group, keys
+}
+
+func newTestProposal() *MultisigProposal {
+	to, _ := crypto.MustGenerateShardKeyPair(0)
+	return ProposeMultisigTx(to, big.NewInt(1), big.NewInt(0), 0)
+}
+
+func TestApproveMultisigTxRejectsOutsideSigner(t *testing.T) {
+	group, _ := newTestGroup(t, 1)
+	proposal := newTestProposal()
+
+	_, outsider := crypto.MustGenerateShardKeyPair(0)
+	if _, err := ApproveMultisigTx(group, proposal, outsider); err == nil {
+		t.Fatal("expected an error approving from outside the group, got nil")
+	}
+}
+
+func TestApproveMultisigTxDedupsSameSigner(t *testing.T) {
+	group, keys := newTestGroup(t, 2)
+	proposal := newTestProposal()
+
+	if _, err := ApproveMultisigTx(group, proposal, keys[0]); err != nil {
+		t.Fatalf("first approval failed: %s", err)
+	}
+
+	count, err := ApproveMultisigTx(group, proposal, keys[0])
+	if err != nil {
+		t.Fatalf("second approval from the same signer failed: %s", err)
+	}
+
+	if count != 1 {
+		t.Errorf("approval count = %d, want 1 (duplicate signer shouldn't be counted twice)", count)
+	}
+}
+
+func TestExecuteMultisigTxRejectsForgedApproval(t *testing.T) {
+	group, keys := newTestGroup(t, 2)
+	proposal := newTestProposal()
+
+	if _, err := ApproveMultisigTx(group, proposal, keys[0]); err != nil {
+		t.Fatalf("approval failed: %s", err)
+	}
+
+	// Forge a second approval claiming to be keys[1] without actually signing
+	// with it.
+	proposal.Approvals = append(proposal.Approvals, Approval{
+		Signer:    crypto.GetAddress(&keys[1].PublicKey).ToHex(),
+		Signature: []byte(`"00"`),
+	})
+
+	digest := proposalDigest(proposal)
+	verified := 0
+	for _, a := range proposal.Approvals {
+		ok, _ := verifyApproval(a, digest)
+		if ok {
+			verified++
+		}
+	}
+
+	if verified >= group.Threshold {
+		t.Fatalf("forged approval was counted toward the threshold: %d verified, threshold %d", verified, group.Threshold)
+	}
+
+	// The threshold isn't reached, so ExecuteMultisigTx returns before it
+	// ever touches the (nil) RPC client.
+	if _, ok := ExecuteMultisigTx(nil, group, keys[0], proposal); ok {
+		t.Fatal("ExecuteMultisigTx accepted a proposal whose second approval is forged")
+	}
+}
+
+func TestVerifyApprovalAcceptsGenuineSignature(t *testing.T) {
+	group, keys := newTestGroup(t, 1)
+	proposal := newTestProposal()
+
+	if _, err := ApproveMultisigTx(group, proposal, keys[0]); err != nil {
+		t.Fatalf("approval failed: %s", err)
+	}
+
+	digest := proposalDigest(proposal)
+	ok, err := verifyApproval(proposal.Approvals[0], digest)
+	if err != nil {
+		t.Fatalf("verifyApproval failed: %s", err)
+	}
+
+	if !ok {
+		t.Fatal("expected a genuine approval to verify, got false")
+	}
+}
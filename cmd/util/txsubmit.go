@@ -0,0 +1,34 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package util
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// NewUnsignedTx builds a transaction with no signature attached, so it can
+// be handed to an arbitrary signer (in-memory key or hardware wallet)
+// instead of Sendtx's built-in ECDSA signing.
+func NewUnsignedTx(from, to common.Address, amount, fee *big.Int, nonce uint64, payload []byte) *types.Transaction {
+	return types.NewTransaction(from, to, amount, fee, nonce, payload)
+}
+
+// SubmitSignedTx submits a transaction that has already been signed, the
+// counterpart to NewUnsignedTx for callers driving their own signing step.
+func SubmitSignedTx(client *rpc.Client, tx *types.Transaction) bool {
+	var result bool
+	if err := client.Call("txpool.AddTx", tx, &result); err != nil {
+		fmt.Println("submit signed tx failed ", err)
+		return false
+	}
+
+	return result
+}